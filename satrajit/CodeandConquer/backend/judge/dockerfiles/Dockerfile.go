@@ -7,8 +7,54 @@ RUN addgroup -g 1001 -S executor && \
 WORKDIR /sandbox
 RUN chown executor:executor /sandbox
 
-# Pre-download common packages
 ENV GOPROXY=https://proxy.golang.org,direct
 ENV GO111MODULE=on
 
+# The base image pins GOPATH=/go, which submissions (running as
+# "executor") can't write to. Repoint it at the executor's own home so
+# GOMODCACHE (GOPATH/pkg/mod) is both writable at runtime and the same
+# directory prewarm.sh populates below.
+ENV GOPATH=/home/executor/go
+
+# Let a submission's go.mod pick a newer Go version than the base image
+# ships; the downloaded toolchain lands in GOMODCACHE alongside regular
+# module downloads. A *named* volume mounted there at `docker run` time
+# lets repeated runs reuse a toolchain instead of re-downloading it:
+#
+#   docker volume create sandbox-go-mod-cache
+#   docker run -v sandbox-go-mod-cache:/home/executor/go/pkg/mod ...
+#
+# Declaring an anonymous VOLUME here instead would not help: every
+# `docker run` gets its own fresh anonymous volume, so the toolchain would
+# still be re-downloaded on each run.
+ENV GOTOOLCHAIN=auto
+
+# Pre-download common packages so the sandbox can later run fully offline
+# (SANDBOX_OFFLINE=1, see entrypoint.sh) without every submission paying a
+# network round-trip or failing on an air-gapped host.
+COPY go/common-packages.txt /sandbox/common-packages.txt
+COPY go/prewarm.sh /usr/local/bin/prewarm.sh
+RUN chmod +x /usr/local/bin/prewarm.sh && \
+    /usr/local/bin/prewarm.sh /sandbox/common-packages.txt
+
+COPY go/allowed-proxies.txt /sandbox/allowed-proxies.txt
+COPY go/entrypoint.sh /usr/local/bin/entrypoint.sh
+RUN chmod +x /usr/local/bin/entrypoint.sh
+
+# Embedded GOPROXY: a tiny in-container server (goproxy-server) that only
+# serves modules mirrored ahead of time into /var/lib/goproxy by
+# goproxy-mirror. Both binaries are built by `make -C backend/judge
+# build-sandbox-tools` before this image is built (see
+# dockerfiles/go/bin/). This is what lets an operator run the sandbox with
+# GOPROXY pointed only at 127.0.0.1 and no outbound network at all, while
+# still supporting a known set of third-party libraries.
+COPY go/bin/goproxy-server /usr/local/bin/goproxy-server
+COPY go/bin/goproxy-mirror /usr/local/bin/goproxy-mirror
+COPY go/goproxy-allowlist.txt /sandbox/goproxy-allowlist.txt
+RUN mkdir -p /var/lib/goproxy && \
+    /usr/local/bin/goproxy-mirror -out /var/lib/goproxy \
+        $(grep -v '^#' /sandbox/goproxy-allowlist.txt | grep -v '^[[:space:]]*$') && \
+    chown -R executor:executor /var/lib/goproxy
+
 USER executor
+ENTRYPOINT ["/usr/local/bin/entrypoint.sh"]
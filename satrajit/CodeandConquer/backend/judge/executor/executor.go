@@ -0,0 +1,161 @@
+// Package executor runs untrusted Go submissions inside the judge sandbox
+// and turns whatever the go command prints on failure into a structured
+// result the rest of the backend can render without re-parsing compiler
+// output.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"regexp"
+	"time"
+)
+
+// ErrorClass categorizes a failed build so API consumers can branch on it
+// (e.g. show a "network disabled" hint) instead of string-matching stderr.
+type ErrorClass string
+
+const (
+	// ErrorClassCompile covers ordinary compile errors in user code.
+	ErrorClassCompile ErrorClass = "compile_error"
+	// ErrorClassMissingModule means the build needed a module that isn't
+	// in the pre-warmed cache and the sandbox has outbound network
+	// disabled (SANDBOX_OFFLINE=1, GOPROXY=off).
+	ErrorClassMissingModule ErrorClass = "missing_module"
+	// ErrorClassTimeout means the build or run exceeded its deadline.
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassChecksumMismatch means a downloaded module's content
+	// didn't match the checksum recorded by GOSUMDB, i.e. the proxy
+	// served something other than what sum.golang.org attests to.
+	ErrorClassChecksumMismatch ErrorClass = "checksum_mismatch"
+)
+
+// Result is the outcome of building and/or running a submission.
+type Result struct {
+	Stdout        string     `json:"stdout"`
+	Stderr        string     `json:"stderr"`
+	ExitCode      int        `json:"exit_code"`
+	ErrorClass    ErrorClass `json:"error_class,omitempty"`
+	MissingPaths  []string   `json:"missing_module_paths,omitempty"`
+	RequestedGo   string     `json:"requested_go_version,omitempty"`
+	ToolchainUsed string     `json:"toolchain_used,omitempty"`
+}
+
+// lookupDisabledPattern confirms a failure is the GOPROXY=off case at all;
+// the offending import is never on this line, e.g.:
+//
+//	main.go:3:8: cannot find module providing package github.com/foo/bar: module lookup disabled by GOPROXY=off
+//	main.go:3:8: module lookup disabled by GOPROXY=off
+var lookupDisabledPattern = regexp.MustCompile(`module lookup disabled by GOPROXY=off`)
+
+// cannotFindPackagePattern captures the package path when `go` already knows
+// which package triggered the lookup, e.g.:
+//
+//	main.go:3:8: cannot find module providing package github.com/foo/bar: module lookup disabled by GOPROXY=off
+var cannotFindPackagePattern = regexp.MustCompile(`(?m)cannot find module providing package (\S+): module lookup disabled by GOPROXY=off`)
+
+// downloadingModulePattern captures the module `go` was about to fetch when
+// the module (as opposed to a specific package within it) is already known,
+// e.g.:
+//
+//	go: downloading github.com/foo/bar v1.2.3
+//
+// This line precedes, rather than names, the "module lookup disabled by
+// GOPROXY=off" line it caused, so it's only trusted as a hit once that
+// line is also present in stderr.
+var downloadingModulePattern = regexp.MustCompile(`(?m)^go: downloading (\S+) \S+$`)
+
+// checksumMismatchPattern matches the "go build"/"go mod download" error
+// emitted when GOSUMDB rejects a module because its hash doesn't match the
+// one recorded in go.sum or published by sum.golang.org, e.g.:
+//
+//	verifying github.com/foo/bar@v1.2.3: checksum mismatch
+var checksumMismatchPattern = regexp.MustCompile(`(?m)verifying (\S+@\S+): checksum mismatch`)
+
+// Build compiles the submission at dir with `go build ./...` and returns a
+// Result classifying any failure. The caller is expected to have already
+// applied sandbox env vars (GOPROXY, GOSUMDB, ...) via go env -w.
+func Build(ctx context.Context, dir string, timeout time.Duration) (Result, error) {
+	// GOTOOLCHAIN=auto will happily download whatever toolchain the
+	// submission's go.mod asks for; check the requested version against
+	// the operator's cap first so an oversized request fails fast
+	// instead of triggering a download.
+	if class, requested, err := checkToolchainCap(dir, os.Getenv("SANDBOX_MAX_GO_VERSION")); err != nil {
+		return Result{}, err
+	} else if class != "" {
+		return Result{ErrorClass: class, RequestedGo: requested}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", "build", "./...")
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+
+	result := Result{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		result.ErrorClass = ErrorClassTimeout
+		return result, nil
+	}
+
+	if err == nil {
+		if used, tErr := toolchainUsed(dir); tErr == nil {
+			result.ToolchainUsed = used
+		}
+		return result, nil
+	}
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		result.ExitCode = exitErr.ExitCode()
+	}
+
+	if lookupDisabledPattern.MatchString(result.Stderr) {
+		result.ErrorClass = ErrorClassMissingModule
+		result.MissingPaths = missingModulePaths(result.Stderr)
+		return result, nil
+	}
+
+	if checksumMismatchPattern.MatchString(result.Stderr) {
+		result.ErrorClass = ErrorClassChecksumMismatch
+		return result, nil
+	}
+
+	result.ErrorClass = ErrorClassCompile
+	return result, nil
+}
+
+// missingModulePaths collects the package/module paths named on the lines
+// that accompany a "module lookup disabled by GOPROXY=off" failure. It may
+// return nil even when that failure occurred, if `go` didn't name the
+// package/module on a line in a format recognized here.
+func missingModulePaths(stderr string) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, m := range cannotFindPackagePattern.FindAllStringSubmatch(stderr, -1) {
+		add(m[1])
+	}
+	for _, m := range downloadingModulePattern.FindAllStringSubmatch(stderr, -1) {
+		add(m[1])
+	}
+
+	return paths
+}
@@ -0,0 +1,60 @@
+package executor
+
+import "testing"
+
+// These fixtures are the verbatim stderr of `GOPROXY=off go build ./...`
+// against real submissions, not hand-authored approximations of it.
+
+func TestMissingModulePathsCannotFindPackage(t *testing.T) {
+	// No go.sum/require entry yet for either import.
+	stderr := "go: finding module for package github.com/gorilla/mux\n" +
+		"go: finding module for package github.com/sirupsen/logrus\n" +
+		"main.go:5:2: cannot find module providing package github.com/gorilla/mux: module lookup disabled by GOPROXY=off\n" +
+		"main.go:4:2: cannot find module providing package github.com/sirupsen/logrus: module lookup disabled by GOPROXY=off\n"
+
+	if !lookupDisabledPattern.MatchString(stderr) {
+		t.Fatalf("lookupDisabledPattern did not match fixture")
+	}
+
+	got := missingModulePaths(stderr)
+	want := []string{"github.com/gorilla/mux", "github.com/sirupsen/logrus"}
+	if len(got) != len(want) {
+		t.Fatalf("missingModulePaths() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("missingModulePaths()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMissingModulePathsDownloading(t *testing.T) {
+	// go.mod already has a require entry, so `go` knows the module but
+	// still needs to fetch it before it can satisfy the import.
+	stderr := "go: downloading github.com/sirupsen/logrus v1.9.3\n" +
+		"main.go:3:8: module lookup disabled by GOPROXY=off\n"
+
+	if !lookupDisabledPattern.MatchString(stderr) {
+		t.Fatalf("lookupDisabledPattern did not match fixture")
+	}
+
+	got := missingModulePaths(stderr)
+	want := []string{"github.com/sirupsen/logrus"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Fatalf("missingModulePaths() = %v, want %v", got, want)
+	}
+}
+
+func TestMissingModulePathsNoMatch(t *testing.T) {
+	stderr := "./main.go:3:2: undefined: fmt.Printlnn\n"
+	if lookupDisabledPattern.MatchString(stderr) {
+		t.Fatalf("lookupDisabledPattern matched an unrelated compile error")
+	}
+}
+
+func TestChecksumMismatchPattern(t *testing.T) {
+	stderr := "go: verifying github.com/foo/bar@v1.2.3: checksum mismatch\n"
+	if !checksumMismatchPattern.MatchString(stderr) {
+		t.Fatalf("checksumMismatchPattern did not match: %q", stderr)
+	}
+}
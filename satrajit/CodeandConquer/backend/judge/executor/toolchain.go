@@ -0,0 +1,105 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// ErrorClassToolchainCapped means the submission's go.mod requested (via
+// its `go` or `toolchain` directive) a Go version above SANDBOX_MAX_GO_VERSION,
+// so GOTOOLCHAIN=auto was never given the chance to download it.
+const ErrorClassToolchainCapped ErrorClass = "toolchain_capped"
+
+// goModVersionPattern matches the `go` and `toolchain` directives in a
+// go.mod file, e.g. "go 1.23.0" or "toolchain go1.23.0".
+var goModVersionPattern = regexp.MustCompile(`(?m)^(?:go|toolchain go) ?(\d+\.\d+(?:\.\d+)?)`)
+
+// requestedGoVersion returns the highest Go version requested by the go.mod
+// at dir/go.mod, i.e. the version GOTOOLCHAIN=auto would try to download.
+// It returns "" if dir has no go.mod or neither directive is present.
+func requestedGoVersion(dir string) (string, error) {
+	data, err := os.ReadFile(dir + "/go.mod")
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	matches := goModVersionPattern.FindAllStringSubmatch(string(data), -1)
+	var highest string
+	for _, m := range matches {
+		if compareGoVersions(m[1], highest) > 0 {
+			highest = m[1]
+		}
+	}
+	return highest, nil
+}
+
+// compareGoVersions compares two dotted Go version strings (e.g. "1.23.0"
+// vs "1.9"). An empty string sorts lowest.
+func compareGoVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return -1
+	}
+	if b == "" {
+		return 1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			fmt.Sscanf(as[i], "%d", &an)
+		}
+		if i < len(bs) {
+			fmt.Sscanf(bs[i], "%d", &bn)
+		}
+		if an != bn {
+			return an - bn
+		}
+	}
+	return 0
+}
+
+// checkToolchainCap returns a non-empty ErrorClass if the submission at dir
+// requests a Go version above maxVersion. An empty maxVersion disables the
+// cap (SANDBOX_MAX_GO_VERSION unset).
+func checkToolchainCap(dir, maxVersion string) (ErrorClass, string, error) {
+	if maxVersion == "" {
+		return "", "", nil
+	}
+	requested, err := requestedGoVersion(dir)
+	if err != nil {
+		return "", "", err
+	}
+	if requested != "" && compareGoVersions(requested, maxVersion) > 0 {
+		return ErrorClassToolchainCapped, requested, nil
+	}
+	return "", "", nil
+}
+
+// toolchainUsed reports the Go toolchain version that actually ran the
+// build, as resolved by `go version` under GOTOOLCHAIN=auto (which honors
+// the submission's go.mod toolchain directive and may differ from the base
+// image's toolchain).
+func toolchainUsed(dir string) (string, error) {
+	cmd := exec.Command("go", "version")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(out))
+	for _, f := range fields {
+		if strings.HasPrefix(f, "go1.") || strings.HasPrefix(f, "go2.") {
+			return f, nil
+		}
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,51 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckToolchainCap(t *testing.T) {
+	dir := t.TempDir()
+	goMod := "module example.com/submission\n\ngo 1.23.0\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	class, requested, err := checkToolchainCap(dir, "1.22")
+	if err != nil {
+		t.Fatalf("checkToolchainCap() error = %v", err)
+	}
+	if class != ErrorClassToolchainCapped {
+		t.Fatalf("checkToolchainCap() class = %q, want %q", class, ErrorClassToolchainCapped)
+	}
+	if requested != "1.23.0" {
+		t.Fatalf("checkToolchainCap() requested = %q, want %q", requested, "1.23.0")
+	}
+
+	class, _, err = checkToolchainCap(dir, "1.24")
+	if err != nil {
+		t.Fatalf("checkToolchainCap() error = %v", err)
+	}
+	if class != "" {
+		t.Fatalf("checkToolchainCap() class = %q, want empty", class)
+	}
+}
+
+func TestCompareGoVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.23.0", "1.22", 1},
+		{"1.9", "1.10", -1},
+		{"1.22", "1.22", 0},
+		{"", "1.22", -1},
+	}
+	for _, c := range cases {
+		if got := compareGoVersions(c.a, c.b); (got > 0) != (c.want > 0) || (got < 0) != (c.want < 0) || (got == 0) != (c.want == 0) {
+			t.Errorf("compareGoVersions(%q, %q) = %d, want sign %d", c.a, c.b, got, c.want)
+		}
+	}
+}
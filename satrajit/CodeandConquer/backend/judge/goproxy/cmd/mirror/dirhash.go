@@ -0,0 +1,62 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// hash1 implements the "h1:" module hash algorithm sum.golang.org and
+// go.sum use (golang.org/x/mod/sumdb/dirhash.Hash1): the SHA-256 of each
+// named file's own SHA-256, concatenated as "<hex digest>  <name>\n" lines
+// sorted by name, then SHA-256'd again and base64-encoded.
+func hash1(files map[string][]byte) (string, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fh := sha256.Sum256(files[name])
+		fmt.Fprintf(h, "%x  %s\n", fh, name)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashGoMod computes the dirhash of a module's go.mod file the way
+// sum.golang.org does: as a single synthetic "<module>@<version>/go.mod"
+// entry.
+func hashGoMod(module, version string, data []byte) (string, error) {
+	return hash1(map[string][]byte{module + "@" + version + "/go.mod": data})
+}
+
+// hashZip computes the dirhash of a module zip the way sum.golang.org
+// does: over every file inside it, keyed by its path as stored in the zip
+// (already in "<module>@<version>/..." form).
+func hashZip(zipData []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return "", fmt.Errorf("reading module zip: %w", err)
+	}
+
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+		files[f.Name] = data
+	}
+	return hash1(files)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestHashGoModDeterministic(t *testing.T) {
+	data := []byte("module \"rsc.io/quote\"\n")
+	got, err := hashGoMod("rsc.io/quote", "v1.5.2", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := hashGoMod("rsc.io/quote", "v1.5.2", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != again {
+		t.Fatalf("hashGoMod() is not deterministic: %q vs %q", got, again)
+	}
+
+	other, err := hashGoMod("rsc.io/quote", "v1.5.3", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == other {
+		t.Fatalf("hashGoMod() ignored version: both v1.5.2 and v1.5.3 hashed to %q", got)
+	}
+}
+
+func TestHashZipRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"example.com/mod@v1.0.0/go.mod":  "module example.com/mod\n",
+		"example.com/mod@v1.0.0/main.go": "package mod\n",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := hashZip(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := hashZip(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != again {
+		t.Fatalf("hashZip() is not deterministic: %q vs %q", got, again)
+	}
+}
@@ -0,0 +1,174 @@
+// Command mirror populates a goproxy.Server root directory by downloading
+// modules from an upstream GOPROXY and verifying each against the dirhash
+// digest published by GOSUMDB, so a build-time allowlist can be generated
+// for the embedded proxy without shipping arbitrary, unverified module
+// contents.
+//
+// Usage:
+//
+//	mirror -upstream https://proxy.golang.org -out /var/lib/goproxy module@version [module@version ...]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	upstream := flag.String("upstream", "https://proxy.golang.org", "upstream GOPROXY to mirror from")
+	out := flag.String("out", "/var/lib/goproxy", "root directory to write mirrored modules into")
+	sumdb := flag.String("sumdb", "https://sum.golang.org", "GOSUMDB server to verify downloads against")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		log.Fatal("mirror: at least one module@version argument is required")
+	}
+
+	for _, arg := range flag.Args() {
+		module, version, ok := strings.Cut(arg, "@")
+		if !ok {
+			log.Fatalf("mirror: %q is not in module@version form", arg)
+		}
+		if err := mirrorOne(*upstream, *sumdb, *out, module, version); err != nil {
+			log.Fatalf("mirror: %s@%s: %v", module, version, err)
+		}
+		fmt.Printf("mirrored %s@%s\n", module, version)
+	}
+}
+
+// mirrorOne downloads the .info, .mod and .zip files for module@version
+// from upstream, verifies the .mod and .zip against the dirhash (H1)
+// published by sumdb, and writes them (plus an @v/list entry) under root.
+func mirrorOne(upstream, sumdb, root, module, version string) error {
+	escaped, err := escapePath(module)
+	if err != nil {
+		return err
+	}
+	destDir := filepath.Join(root, escaped, "@v")
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return err
+	}
+
+	digests, err := lookupSumdb(sumdb, module, version)
+	if err != nil {
+		return fmt.Errorf("querying sumdb: %w", err)
+	}
+
+	contents := map[string][]byte{}
+	for _, ext := range []string{".info", ".mod", ".zip"} {
+		url := fmt.Sprintf("%s/%s/@v/%s%s", upstream, escaped, version, ext)
+		data, err := fetch(url)
+		if err != nil {
+			return fmt.Errorf("fetching %s: %w", ext, err)
+		}
+		contents[ext] = data
+	}
+
+	// The .info file isn't part of the sumdb record; only .mod and .zip
+	// have published dirhash digests to check.
+	modHash, err := hashGoMod(module, version, contents[".mod"])
+	if err != nil {
+		return err
+	}
+	if modHash != digests[".mod"] {
+		return fmt.Errorf("checksum mismatch for %s.mod: got %s, want %s", version, modHash, digests[".mod"])
+	}
+
+	zipHash, err := hashZip(contents[".zip"])
+	if err != nil {
+		return err
+	}
+	if zipHash != digests[".zip"] {
+		return fmt.Errorf("checksum mismatch for %s.zip: got %s, want %s", version, zipHash, digests[".zip"])
+	}
+
+	for _, ext := range []string{".info", ".mod", ".zip"} {
+		if err := os.WriteFile(filepath.Join(destDir, version+ext), contents[ext], 0o644); err != nil {
+			return err
+		}
+	}
+
+	return appendListEntry(filepath.Join(destDir, "list"), version)
+}
+
+// lookupSumdb fetches the dirhash (H1) digests sumdb publishes for a
+// module's .mod and .zip files, e.g. "h1:bNw5pLE...=".
+func lookupSumdb(sumdb, module, version string) (map[string]string, error) {
+	escaped, err := escapePath(module)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fetch(fmt.Sprintf("%s/lookup/%s@%s", sumdb, escaped, version))
+	if err != nil {
+		return nil, err
+	}
+
+	digests := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		switch {
+		case fields[1] == version:
+			digests[".zip"] = fields[2]
+		case fields[1] == version+"/go.mod":
+			digests[".mod"] = fields[2]
+		}
+	}
+	return digests, nil
+}
+
+func appendListEntry(listPath, version string) error {
+	existing, err := os.ReadFile(listPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if line == version {
+			return nil
+		}
+	}
+	f, err := os.OpenFile(listPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, version)
+	return err
+}
+
+func fetch(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// escapePath implements the module path escaping used by the GOPROXY
+// protocol: every uppercase letter is replaced by '!' followed by its
+// lowercase form, so the on-disk/URL form of a path is case-insensitive-
+// filesystem safe.
+func escapePath(modPath string) (string, error) {
+	var b strings.Builder
+	for _, r := range modPath {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			b.WriteRune(r - 'A' + 'a')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestEscapePath(t *testing.T) {
+	cases := map[string]string{
+		"github.com/foo/bar":         "github.com/foo/bar",
+		"github.com/BurntSushi/toml": "github.com/!burnt!sushi/toml",
+	}
+	for in, want := range cases {
+		got, err := escapePath(in)
+		if err != nil {
+			t.Fatalf("escapePath(%q) error = %v", in, err)
+		}
+		if got != want {
+			t.Errorf("escapePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Command server runs goproxy.Server as a standalone HTTP server, for use
+// as the sandbox's embedded GOPROXY (see SANDBOX_GOPROXY=http://127.0.0.1:8081
+// in entrypoint.sh).
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/satrajitghosh183/Code_and_Conquer/backend/judge/goproxy"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8081", "address to listen on")
+	root := flag.String("root", "/var/lib/goproxy", "root directory of mirrored modules")
+	flag.Parse()
+
+	srv := goproxy.NewServer(*root)
+	log.Printf("goproxy: serving %s on %s", *root, *addr)
+	log.Fatal(http.ListenAndServe(*addr, srv))
+}
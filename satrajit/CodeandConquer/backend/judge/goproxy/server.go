@@ -0,0 +1,71 @@
+// Package goproxy implements a minimal read-only server for the GOPROXY
+// HTTP protocol (see https://go.dev/ref/mod#goproxy-protocol), serving only
+// modules that were mirrored ahead of time into a root directory by the
+// cmd/mirror tool in this package.
+//
+// It exists so the judge sandbox can run with no outbound network at all
+// (GOPROXY pointed at this server, with "direct" removed) while still
+// supporting a curated set of third-party modules, rather than being
+// limited to whatever was pre-warmed into GOMODCACHE at image build time.
+package goproxy
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Server serves the GOPROXY protocol from Root, a directory laid out by
+// cmd/mirror as:
+//
+//	<Root>/<escaped-module-path>/@v/list
+//	<Root>/<escaped-module-path>/@v/<escaped-version>.info
+//	<Root>/<escaped-module-path>/@v/<escaped-version>.mod
+//	<Root>/<escaped-module-path>/@v/<escaped-version>.zip
+type Server struct {
+	Root string
+}
+
+// NewServer returns a Server reading mirrored modules from root (typically
+// /var/lib/goproxy).
+func NewServer(root string) *Server {
+	return &Server{Root: root}
+}
+
+// ServeHTTP implements the handful of GOPROXY endpoints the `go` command
+// needs for module resolution and download. Anything else, or any path
+// that escapes Root, is a 404 — this server has no notion of "direct"
+// fallback, matching an image built with GOPROXY=http://127.0.0.1:8081
+// and no further proxy in the chain.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	full := filepath.Join(s.Root, filepath.FromSlash(path))
+
+	// filepath.Join cleans ".." segments, but guard explicitly against
+	// the result landing outside Root before we ever call os.Open.
+	if !strings.HasPrefix(full, filepath.Clean(s.Root)+string(filepath.Separator)) {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(full)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeContent(w, r, full, info.ModTime(), f)
+}
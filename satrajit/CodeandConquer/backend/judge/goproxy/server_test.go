@@ -0,0 +1,55 @@
+package goproxy
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestServerServesMirroredFile(t *testing.T) {
+	root := t.TempDir()
+	modDir := filepath.Join(root, "github.com/foo/bar/@v")
+	if err := os.MkdirAll(modDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "list"), []byte("v1.0.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := NewServer(root)
+	req := httptest.NewRequest("GET", "/github.com/foo/bar/@v/list", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("ServeHTTP() status = %d, want 200", rec.Code)
+	}
+	if got := rec.Body.String(); got != "v1.0.0\n" {
+		t.Fatalf("ServeHTTP() body = %q, want %q", got, "v1.0.0\n")
+	}
+}
+
+func TestServerRejectsPathEscape(t *testing.T) {
+	root := t.TempDir()
+	srv := NewServer(root)
+	req := httptest.NewRequest("GET", "/../../../etc/passwd", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != 404 {
+		t.Fatalf("ServeHTTP() status = %d, want 404", rec.Code)
+	}
+}
+
+func TestServerMissingModuleIs404(t *testing.T) {
+	root := t.TempDir()
+	srv := NewServer(root)
+	req := httptest.NewRequest("GET", "/github.com/not/mirrored/@v/list", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != 404 {
+		t.Fatalf("ServeHTTP() status = %d, want 404", rec.Code)
+	}
+}